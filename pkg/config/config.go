@@ -0,0 +1,203 @@
+// Package config defines picoclaw's on-disk configuration schema and the
+// defaults used when a field is left unset.
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Config is the top-level configuration object loaded from picoclaw.yaml.
+type Config struct {
+	Agents    AgentsConfig    `yaml:"agents"`
+	Gateway   GatewayConfig   `yaml:"gateway"`
+	Providers ProvidersConfig `yaml:"providers"`
+	Channels  ChannelsConfig  `yaml:"channels"`
+	Tools     ToolsConfig     `yaml:"tools"`
+	Heartbeat HeartbeatConfig `yaml:"heartbeat"`
+	Sessions  SessionsConfig  `yaml:"sessions"`
+}
+
+// SessionsConfig selects and configures the session persistence backend.
+type SessionsConfig struct {
+	// Backend selects the SessionStore implementation: "file" (default),
+	// "sqlite" or "memory".
+	Backend string `yaml:"backend"`
+	// Dir is the filesystem backend's session directory, or the directory
+	// the sqlite backend's database file lives in.
+	Dir string `yaml:"dir"`
+	// SQLitePath is the sqlite backend's database file path. Defaults to
+	// "<Dir>/sessions.db" when unset.
+	SQLitePath string `yaml:"sqlite_path,omitempty"`
+}
+
+// AgentsConfig groups per-agent defaults.
+type AgentsConfig struct {
+	Defaults AgentDefaults `yaml:"defaults"`
+}
+
+// AgentDefaults are applied to an agent whenever a field isn't overridden.
+type AgentDefaults struct {
+	Workspace         string  `yaml:"workspace"`
+	Model             string  `yaml:"model"`
+	MaxTokens         int     `yaml:"max_tokens"`
+	MaxToolIterations int     `yaml:"max_tool_iterations"`
+	Temperature       float64 `yaml:"temperature"`
+}
+
+// GatewayConfig controls the HTTP server picoclaw exposes for inbound
+// integrations (webhooks, health checks, etc).
+type GatewayConfig struct {
+	Host string `yaml:"host"`
+	Port int    `yaml:"port"`
+}
+
+// ProvidersConfig holds API credentials for each supported LLM provider.
+type ProvidersConfig struct {
+	Anthropic  ProviderConfig `yaml:"anthropic"`
+	OpenAI     ProviderConfig `yaml:"openai"`
+	OpenRouter ProviderConfig `yaml:"openrouter"`
+	Groq       ProviderConfig `yaml:"groq"`
+	Zhipu      ProviderConfig `yaml:"zhipu"`
+	VLLM       ProviderConfig `yaml:"vllm"`
+	Gemini     ProviderConfig `yaml:"gemini"`
+}
+
+// ProviderConfig is the shared shape for every LLM provider entry.
+type ProviderConfig struct {
+	APIKey  string `yaml:"api_key"`
+	BaseURL string `yaml:"base_url,omitempty"`
+}
+
+// ChannelsConfig enables/disables and configures each supported chat channel.
+type ChannelsConfig struct {
+	WhatsApp ChannelConfig `yaml:"whatsapp"`
+	Telegram ChannelConfig `yaml:"telegram"`
+	Feishu   ChannelConfig `yaml:"feishu"`
+	Discord  ChannelConfig `yaml:"discord"`
+	MaixCam  ChannelConfig `yaml:"maixcam"`
+	QQ       ChannelConfig `yaml:"qq"`
+	DingTalk ChannelConfig `yaml:"dingtalk"`
+	Slack    ChannelConfig `yaml:"slack"`
+}
+
+// ChannelConfig is the shared shape for every channel adapter entry.
+type ChannelConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// IncomingWebhooks maps a webhook name to its token configuration,
+	// allowing external services to push messages into this channel's
+	// agent without speaking the channel's native protocol.
+	IncomingWebhooks map[string]IncomingWebhookConfig `yaml:"incoming_webhooks,omitempty"`
+}
+
+// IncomingWebhookConfig describes a single `/hooks/{token}` endpoint bound
+// to a channel.
+type IncomingWebhookConfig struct {
+	// Token is the opaque path segment callers present as /hooks/{token}.
+	Token string `yaml:"token"`
+	// Secret is the HMAC key used to verify X-Signature-256, when set.
+	Secret string `yaml:"secret,omitempty"`
+	// AllowedIPs restricts which source IPs/CIDRs may call this webhook.
+	// Empty means no IP restriction.
+	AllowedIPs []string `yaml:"allowed_ips,omitempty"`
+	// RateLimitPerMinute caps requests accepted per minute for this token.
+	// Zero means unlimited.
+	RateLimitPerMinute int `yaml:"rate_limit_per_minute,omitempty"`
+}
+
+// ToolsConfig configures built-in agent tools.
+type ToolsConfig struct {
+	Web WebToolsConfig `yaml:"web"`
+}
+
+// WebToolsConfig configures the web search/fetch tools.
+type WebToolsConfig struct {
+	Brave      SearchProviderConfig `yaml:"brave"`
+	Exa        SearchProviderConfig `yaml:"exa"`
+	DuckDuckGo SearchProviderConfig `yaml:"duckduckgo"`
+	Fetch      WebFetchConfig       `yaml:"fetch"`
+
+	// FusionEnabled controls whether web_search merges results from
+	// multiple enabled providers via Reciprocal Rank Fusion instead of
+	// picking a single one. Defaults to true once >=2 providers are
+	// enabled; set explicitly to override.
+	FusionEnabled *bool `yaml:"fusion_enabled,omitempty"`
+}
+
+// SearchProviderConfig configures one search provider.
+type SearchProviderConfig struct {
+	Enabled    bool   `yaml:"enabled"`
+	APIKey     string `yaml:"api_key"`
+	MaxResults int    `yaml:"max_results"`
+}
+
+// WebFetchConfig configures the WebFetchTool's politeness layer.
+type WebFetchConfig struct {
+	// UserAgent is sent on every fetch. Defaults to a picoclaw identifier.
+	UserAgent string `yaml:"user_agent"`
+	// RespectRobots controls whether robots.txt is consulted before a
+	// fetch. Defaults to true; set false to bypass (logged when used).
+	RespectRobots bool `yaml:"respect_robots"`
+	// RobotsCacheTTLSeconds controls how long a fetched robots.txt is
+	// cached before being re-fetched. Defaults to 3600 (1 hour).
+	RobotsCacheTTLSeconds int `yaml:"robots_cache_ttl_seconds"`
+}
+
+// HeartbeatConfig controls the periodic liveness ping agents send.
+type HeartbeatConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// DefaultConfig returns a Config populated with picoclaw's out-of-the-box
+// defaults. Callers typically merge a user config file on top of this.
+func DefaultConfig() *Config {
+	return &Config{
+		Agents: AgentsConfig{
+			Defaults: AgentDefaults{
+				Workspace:         expandHome("~/.picoclaw/workspace"),
+				Model:             "claude-sonnet-4-5",
+				MaxTokens:         8192,
+				MaxToolIterations: 25,
+				Temperature:       0.7,
+			},
+		},
+		Gateway: GatewayConfig{
+			Host: "0.0.0.0",
+			Port: 8080,
+		},
+		Channels: ChannelsConfig{},
+		Tools: ToolsConfig{
+			Web: WebToolsConfig{
+				Brave:      SearchProviderConfig{MaxResults: 5},
+				Exa:        SearchProviderConfig{MaxResults: 5},
+				DuckDuckGo: SearchProviderConfig{MaxResults: 5},
+				Fetch: WebFetchConfig{
+					UserAgent:             "picoclaw/dev (+https://github.com/yimiaoxiehou/picoclaw)",
+					RespectRobots:         true,
+					RobotsCacheTTLSeconds: 3600,
+				},
+			},
+		},
+		Heartbeat: HeartbeatConfig{Enabled: true},
+		Sessions: SessionsConfig{
+			Backend: "file",
+			Dir:     expandHome("~/.picoclaw/sessions"),
+		},
+	}
+}
+
+// expandHome expands a leading "~" into the current user's home directory.
+func expandHome(path string) string {
+	if path == "~" || len(path) >= 2 && path[:2] == "~/" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return path
+		}
+		if path == "~" {
+			return home
+		}
+		return filepath.Join(home, path[2:])
+	}
+	return path
+}
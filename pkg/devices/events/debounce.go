@@ -0,0 +1,88 @@
+package events
+
+import "time"
+
+// debounceEvents coalesces a remove immediately followed by an add (or
+// vice versa) of the same device within window into a single
+// ActionChange event, which is what a cable reseat or a USB hub
+// re-enumerating looks like to the kernel. A window of 0 disables
+// coalescing and events are forwarded unmodified.
+func debounceEvents(in <-chan *DeviceEvent, out chan<- *DeviceEvent, window time.Duration, stop <-chan struct{}) {
+	defer close(out)
+
+	if window <= 0 {
+		for {
+			select {
+			case ev, ok := <-in:
+				if !ok {
+					return
+				}
+				out <- ev
+			case <-stop:
+				return
+			}
+		}
+	}
+
+	pending := make(map[string]*DeviceEvent)
+	timers := make(map[string]*time.Timer)
+	fired := make(chan string, 16)
+
+	// closed unblocks any AfterFunc callback still trying to send on
+	// fired after the loop below has returned, so a burst of timers
+	// expiring around shutdown can't leak goroutines forever.
+	closed := make(chan struct{})
+	stopTimers := func() {
+		close(closed)
+		for _, tm := range timers {
+			tm.Stop()
+		}
+	}
+
+	flush := func(id string) {
+		if ev, ok := pending[id]; ok {
+			out <- ev
+			delete(pending, id)
+			delete(timers, id)
+		}
+	}
+
+	for {
+		select {
+		case ev, ok := <-in:
+			if !ok {
+				stopTimers()
+				for id := range pending {
+					flush(id)
+				}
+				return
+			}
+
+			if prev, ok := pending[ev.DeviceID]; ok && prev.Action != ev.Action {
+				timers[ev.DeviceID].Stop()
+				delete(timers, ev.DeviceID)
+				merged := *ev
+				merged.Action = ActionChange
+				out <- &merged
+				delete(pending, ev.DeviceID)
+				continue
+			}
+
+			pending[ev.DeviceID] = ev
+			id := ev.DeviceID
+			timers[id] = time.AfterFunc(window, func() {
+				select {
+				case fired <- id:
+				case <-closed:
+				}
+			})
+
+		case id := <-fired:
+			flush(id)
+
+		case <-stop:
+			stopTimers()
+			return
+		}
+	}
+}
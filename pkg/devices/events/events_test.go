@@ -0,0 +1,126 @@
+package events
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDecodeInterfaceClass(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected string
+	}{
+		{"08", "Mass Storage"},
+		{"03", "HID"},
+		{"0e", "Video"},
+		{"0x08", "Mass Storage"},
+		{"", ""},
+		{"999", ""},
+	}
+
+	for _, tt := range tests {
+		if got := decodeInterfaceClass(tt.raw); got != tt.expected {
+			t.Errorf("decodeInterfaceClass(%q) = %q, want %q", tt.raw, got, tt.expected)
+		}
+	}
+}
+
+func TestFakeSource_ReplaysEvents(t *testing.T) {
+	events := []*DeviceEvent{
+		{Action: ActionAdd, Kind: KindUSB, DeviceID: "1-1", Vendor: "Acme"},
+		{Action: ActionRemove, Kind: KindUSB, DeviceID: "1-1", Vendor: "Acme"},
+	}
+	src := NewFakeSource(KindUSB, events)
+
+	ch, err := src.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	var got []*DeviceEvent
+	for ev := range ch {
+		got = append(got, ev)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 replayed events, got %d", len(got))
+	}
+	if got[0].Action != ActionAdd || got[1].Action != ActionRemove {
+		t.Errorf("events replayed out of order: %+v", got)
+	}
+}
+
+func TestDebounceEvents_CoalescesAddRemove(t *testing.T) {
+	in := make(chan *DeviceEvent)
+	out := make(chan *DeviceEvent, 4)
+	stop := make(chan struct{})
+	defer close(stop)
+
+	go debounceEvents(in, out, 50*time.Millisecond, stop)
+
+	in <- &DeviceEvent{Action: ActionRemove, DeviceID: "1-1", Kind: KindUSB}
+	in <- &DeviceEvent{Action: ActionAdd, DeviceID: "1-1", Kind: KindUSB}
+
+	select {
+	case ev := <-out:
+		if ev.Action != ActionChange {
+			t.Fatalf("expected coalesced event to report ActionChange, got %v", ev.Action)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for coalesced event")
+	}
+}
+
+func TestDebounceEvents_PassesThroughUnrelatedEvents(t *testing.T) {
+	in := make(chan *DeviceEvent)
+	out := make(chan *DeviceEvent, 4)
+	stop := make(chan struct{})
+	defer close(stop)
+
+	go debounceEvents(in, out, 20*time.Millisecond, stop)
+
+	in <- &DeviceEvent{Action: ActionAdd, DeviceID: "1-1", Kind: KindUSB}
+
+	select {
+	case ev := <-out:
+		if ev.Action != ActionAdd {
+			t.Fatalf("expected passthrough ActionAdd, got %v", ev.Action)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for debounced event to flush")
+	}
+}
+
+func TestPollingSource_DetectsAddedDevice(t *testing.T) {
+	tmpRoot := t.TempDir()
+	if err := os.MkdirAll(tmpRoot+"/bus/usb/devices/1-1", 0o755); err != nil {
+		t.Fatalf("failed to set up fake sysfs tree: %v", err)
+	}
+	if err := os.WriteFile(tmpRoot+"/bus/usb/devices/1-1/idVendor", []byte("1d6b"), 0o644); err != nil {
+		t.Fatalf("failed to write fake sysfs attribute: %v", err)
+	}
+
+	src := NewPollingSource(20 * time.Millisecond)
+	src.sysPath = tmpRoot
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := src.Start(ctx)
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	select {
+	case ev := <-ch:
+		if ev.Action != ActionAdd || ev.Vendor != "1d6b" {
+			t.Fatalf("expected add event with vendor 1d6b, got %+v", ev)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for polled add event")
+	}
+
+	src.Stop()
+}
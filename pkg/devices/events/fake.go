@@ -0,0 +1,43 @@
+package events
+
+import "context"
+
+// FakeSource replays a fixed, pre-recorded sequence of DeviceEvents. It
+// implements EventSource so tests can exercise downstream consumers
+// (debounce, FormatMessage, notification plumbing) without real hardware
+// or root access to a netlink socket.
+type FakeSource struct {
+	kind   Kind
+	events []*DeviceEvent
+
+	stopCh chan struct{}
+}
+
+// NewFakeSource returns a source that kind-reports as kind and emits
+// events, in order, as soon as Start is called.
+func NewFakeSource(kind Kind, events []*DeviceEvent) *FakeSource {
+	return &FakeSource{kind: kind, events: events}
+}
+
+func (s *FakeSource) Kind() Kind { return s.kind }
+
+func (s *FakeSource) Start(ctx context.Context) (<-chan *DeviceEvent, error) {
+	s.stopCh = make(chan struct{})
+	out := make(chan *DeviceEvent, len(s.events))
+	for _, ev := range s.events {
+		out <- ev
+	}
+	close(out)
+	return out, nil
+}
+
+func (s *FakeSource) Stop() error {
+	if s.stopCh != nil {
+		select {
+		case <-s.stopCh:
+		default:
+			close(s.stopCh)
+		}
+	}
+	return nil
+}
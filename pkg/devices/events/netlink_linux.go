@@ -0,0 +1,165 @@
+//go:build linux
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// NetlinkUEventSource listens for Linux kernel device hotplug events over
+// an AF_NETLINK/NETLINK_KOBJECT_UEVENT socket and decodes them into
+// DeviceEvents.
+type NetlinkUEventSource struct {
+	debounce time.Duration
+
+	fd     int
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// NewNetlinkUEventSource returns a source that coalesces an add+remove of
+// the same device within debounce into a single ActionChange event. A
+// debounce of 0 disables coalescing.
+func NewNetlinkUEventSource(debounce time.Duration) *NetlinkUEventSource {
+	return &NetlinkUEventSource{debounce: debounce}
+}
+
+func (s *NetlinkUEventSource) Kind() Kind { return KindGeneric }
+
+// Start opens the netlink socket and begins emitting DeviceEvents on the
+// returned channel until Stop is called or ctx is cancelled.
+func (s *NetlinkUEventSource) Start(ctx context.Context) (<-chan *DeviceEvent, error) {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_RAW, netlinkKObjectUEvent)
+	if err != nil {
+		return nil, fmt.Errorf("events: opening netlink socket: %w", err)
+	}
+
+	addr := &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK, Groups: 1}
+	if err := syscall.Bind(fd, addr); err != nil {
+		syscall.Close(fd)
+		return nil, fmt.Errorf("events: binding netlink socket: %w", err)
+	}
+
+	s.fd = fd
+	s.stopCh = make(chan struct{})
+	s.doneCh = make(chan struct{})
+
+	out := make(chan *DeviceEvent, 16)
+	raw := make(chan *DeviceEvent, 16)
+
+	go s.readLoop(raw)
+	go debounceEvents(raw, out, s.debounce, s.stopCh)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.Stop()
+		case <-s.stopCh:
+		}
+	}()
+
+	return out, nil
+}
+
+// netlinkKObjectUEvent mirrors the kernel's NETLINK_KOBJECT_UEVENT=15,
+// which has no syscall package constant.
+const netlinkKObjectUEvent = 15
+
+func (s *NetlinkUEventSource) readLoop(out chan<- *DeviceEvent) {
+	defer close(s.doneCh)
+
+	buf := make([]byte, 64*1024)
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		default:
+		}
+
+		n, _, err := syscall.Recvfrom(s.fd, buf, 0)
+		if err != nil {
+			return
+		}
+		ev := parseUEvent(buf[:n])
+		if ev != nil {
+			out <- ev
+		}
+	}
+}
+
+func (s *NetlinkUEventSource) Stop() error {
+	select {
+	case <-s.stopCh:
+		// already stopped
+	default:
+		close(s.stopCh)
+		syscall.Close(s.fd)
+	}
+	return nil
+}
+
+// parseUEvent decodes a raw NETLINK_KOBJECT_UEVENT payload. The kernel
+// sends a header line ("add@/devices/...") followed by NUL-separated
+// KEY=VALUE pairs; udevd-compatible payloads ("libudev" prefixed) aren't
+// handled since we talk to the kernel socket directly, not udevd's.
+func parseUEvent(payload []byte) *DeviceEvent {
+	fields := strings.Split(string(payload), "\x00")
+	if len(fields) == 0 {
+		return nil
+	}
+
+	props := make(map[string]string)
+	for _, f := range fields[1:] {
+		if f == "" {
+			continue
+		}
+		kv := strings.SplitN(f, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		props[kv[0]] = kv[1]
+	}
+
+	action, ok := props["ACTION"]
+	if !ok {
+		return nil
+	}
+
+	ev := &DeviceEvent{
+		Action:   Action(action),
+		DeviceID: props["DEVPATH"],
+		Raw:      props,
+	}
+
+	switch strings.ToLower(props["SUBSYSTEM"]) {
+	case "usb":
+		ev.Kind = KindUSB
+	case "bluetooth":
+		ev.Kind = KindBluetooth
+	case "pci":
+		ev.Kind = KindPCI
+	default:
+		ev.Kind = KindGeneric
+	}
+
+	devPath := props["DEVPATH"]
+	if devPath != "" {
+		sysPath := "/sys" + devPath
+		ev.Vendor = firstNonEmpty(
+			readSysAttr(sysPath, "manufacturer"),
+			readSysAttr(sysPath, "idVendor"),
+		)
+		ev.Product = firstNonEmpty(
+			readSysAttr(sysPath, "product"),
+			readSysAttr(sysPath, "idProduct"),
+		)
+		ev.Serial = readSysAttr(sysPath, "serial")
+		ev.Capabilities = decodeInterfaceClass(readSysAttr(sysPath, "bInterfaceClass"))
+	}
+
+	return ev
+}
@@ -0,0 +1,120 @@
+package events
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// PollingSource scans /sys/bus/{usb,pci}/devices on an interval and diffs
+// successive snapshots to synthesize add/remove events. It's the fallback
+// for non-Linux hosts, or Linux hosts where NETLINK_KOBJECT_UEVENT isn't
+// available (e.g. inside restrictive containers).
+type PollingSource struct {
+	interval time.Duration
+	sysPath  string // overridable in tests; defaults to "/sys"
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// NewPollingSource returns a source that re-scans sysfs every interval.
+func NewPollingSource(interval time.Duration) *PollingSource {
+	return &PollingSource{interval: interval, sysPath: "/sys"}
+}
+
+func (s *PollingSource) Kind() Kind { return KindGeneric }
+
+func (s *PollingSource) Start(ctx context.Context) (<-chan *DeviceEvent, error) {
+	s.stopCh = make(chan struct{})
+	s.doneCh = make(chan struct{})
+	out := make(chan *DeviceEvent, 16)
+
+	go func() {
+		defer close(s.doneCh)
+		defer close(out)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		seen := map[string]*DeviceEvent{}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.stopCh:
+				return
+			case <-ticker.C:
+				current := s.scan()
+				for id, ev := range current {
+					if _, ok := seen[id]; !ok {
+						added := *ev
+						added.Action = ActionAdd
+						out <- &added
+					}
+				}
+				for id, ev := range seen {
+					if _, ok := current[id]; !ok {
+						removed := *ev
+						removed.Action = ActionRemove
+						out <- &removed
+					}
+				}
+				seen = current
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func (s *PollingSource) Stop() error {
+	select {
+	case <-s.stopCh:
+	default:
+		close(s.stopCh)
+	}
+	<-s.doneCh
+	return nil
+}
+
+// scan reads every device directory under {usb,pci}/devices and returns a
+// snapshot keyed by "<kind>:<device directory name>", e.g. "usb:1-1".
+func (s *PollingSource) scan() map[string]*DeviceEvent {
+	result := make(map[string]*DeviceEvent)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for kind, bus := range map[Kind]string{KindUSB: "usb", KindPCI: "pci"} {
+		kind, bus := kind, bus
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			busDir := filepath.Join(s.sysPath, "bus", bus, "devices")
+			entries, err := os.ReadDir(busDir)
+			if err != nil {
+				return
+			}
+			for _, e := range entries {
+				devPath := filepath.Join(busDir, e.Name())
+				ev := &DeviceEvent{
+					Kind:     kind,
+					DeviceID: e.Name(),
+					Vendor:   firstNonEmpty(readSysAttr(devPath, "manufacturer"), readSysAttr(devPath, "idVendor")),
+					Product:  firstNonEmpty(readSysAttr(devPath, "product"), readSysAttr(devPath, "idProduct")),
+					Serial:   readSysAttr(devPath, "serial"),
+				}
+				if ev.Kind == KindUSB {
+					ev.Capabilities = decodeInterfaceClass(readSysAttr(devPath, "bInterfaceClass"))
+				}
+				mu.Lock()
+				result[string(kind)+":"+e.Name()] = ev
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return result
+}
@@ -0,0 +1,77 @@
+package events
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// readSysAttr reads a single-line sysfs attribute file, e.g.
+// "/sys/devices/.../idVendor". It returns "" if the attribute doesn't
+// exist, which is common (not every device exposes every attribute).
+func readSysAttr(sysPath, attr string) string {
+	data, err := os.ReadFile(sysPath + "/" + attr)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// firstNonEmpty returns the first non-empty value, or "" if all are empty.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// usbInterfaceClasses maps a USB bInterfaceClass code to a human-readable
+// capability, per the USB-IF class code registry.
+var usbInterfaceClasses = map[string]string{
+	"1":   "Audio",
+	"2":   "Communications",
+	"3":   "HID",
+	"5":   "Physical",
+	"6":   "Image",
+	"7":   "Printer",
+	"8":   "Mass Storage",
+	"9":   "Hub",
+	"10":  "CDC Data",
+	"11":  "Smart Card",
+	"13":  "Content Security",
+	"14":  "Video",
+	"15":  "Personal Healthcare",
+	"16":  "Audio/Video",
+	"220": "Diagnostic",
+	"224": "Wireless Controller",
+	"239": "Miscellaneous",
+	"254": "Application Specific",
+	"255": "Vendor Specific",
+}
+
+// decodeInterfaceClass turns a bInterfaceClass sysfs value (decimal,
+// "0x"-prefixed hex, or bare two-digit hex as sysfs commonly reports it)
+// into a human-readable capability, e.g. "08" -> "Mass Storage".
+func decodeInterfaceClass(raw string) string {
+	if raw == "" {
+		return ""
+	}
+
+	var class int64
+	var err error
+	switch {
+	case strings.HasPrefix(raw, "0x"):
+		class, err = strconv.ParseInt(raw[2:], 16, 64)
+	case len(raw) == 2:
+		class, err = strconv.ParseInt(raw, 16, 64)
+	default:
+		class, err = strconv.ParseInt(raw, 10, 64)
+	}
+	if err != nil {
+		return ""
+	}
+
+	return usbInterfaceClasses[strconv.FormatInt(class, 10)]
+}
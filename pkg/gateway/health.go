@@ -0,0 +1,157 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// Build metadata, set via -ldflags at release time. Left at their
+// defaults ("dev" and "unknown") during local/dev builds.
+var (
+	Version = "dev"
+	Commit  = "unknown"
+)
+
+// HealthChecker is implemented by any subsystem (session store, event
+// source, channel adapter, ...) that wants to participate in /readyz.
+// Packages register their checker at startup rather than the gateway
+// hard-coding what "ready" means.
+type HealthChecker interface {
+	// Name identifies this check in the /readyz report, e.g. "session-store"
+	// or "channel:telegram".
+	Name() string
+	// Check reports readiness. A non-nil error means the check failed and
+	// its Error() string is surfaced to callers.
+	Check() error
+}
+
+// HealthCheckerFunc adapts a plain function to a HealthChecker.
+type HealthCheckerFunc struct {
+	CheckName string
+	Fn        func() error
+}
+
+func (f HealthCheckerFunc) Name() string { return f.CheckName }
+func (f HealthCheckerFunc) Check() error { return f.Fn() }
+
+// InfoProvider supplies a piece of the /info response, such as the list of
+// enabled tools or channels for a particular subsystem.
+type InfoProvider interface {
+	// InfoKey is the top-level JSON key this provider's value is nested
+	// under, e.g. "channels" or "tools".
+	InfoKey() string
+	// Info returns the JSON-serializable value for InfoKey.
+	Info() interface{}
+}
+
+// HealthRegistry collects HealthCheckers and InfoProviders registered by
+// subsystems at startup and serves /healthz, /readyz and /info from them.
+type HealthRegistry struct {
+	mu       sync.Mutex
+	checkers []HealthChecker
+	info     []InfoProvider
+}
+
+// NewHealthRegistry returns an empty registry. Subsystems call
+// RegisterChecker/RegisterInfo during startup, before RegisterRoutes is
+// called.
+func NewHealthRegistry() *HealthRegistry {
+	return &HealthRegistry{}
+}
+
+// RegisterChecker adds c to the set consulted by /readyz.
+func (r *HealthRegistry) RegisterChecker(c HealthChecker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checkers = append(r.checkers, c)
+}
+
+// RegisterInfo adds p to the set consulted by /info.
+func (r *HealthRegistry) RegisterInfo(p InfoProvider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.info = append(r.info, p)
+}
+
+// RegisterRoutes mounts /healthz, /readyz and /info on mux.
+func (r *HealthRegistry) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/healthz", r.handleHealthz)
+	mux.HandleFunc("/readyz", r.handleReadyz)
+	mux.HandleFunc("/info", r.handleInfo)
+}
+
+// handleHealthz is a cheap liveness probe: if the process can respond at
+// all, it's alive. It does not consult registered checkers.
+func (r *HealthRegistry) handleHealthz(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"status":"ok"}`))
+}
+
+type readyCheckResult struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type readyzResponse struct {
+	Status string             `json:"status"`
+	Checks []readyCheckResult `json:"checks"`
+}
+
+// handleReadyz aggregates every registered HealthChecker and returns 503
+// with the failing checks listed whenever at least one fails.
+func (r *HealthRegistry) handleReadyz(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	checkers := make([]HealthChecker, len(r.checkers))
+	copy(checkers, r.checkers)
+	r.mu.Unlock()
+
+	resp := readyzResponse{Status: "ok"}
+	allOK := true
+	for _, c := range checkers {
+		result := readyCheckResult{Name: c.Name(), Status: "ok"}
+		if err := c.Check(); err != nil {
+			result.Status = "failed"
+			result.Error = err.Error()
+			allOK = false
+		}
+		resp.Checks = append(resp.Checks, result)
+	}
+	sort.Slice(resp.Checks, func(i, j int) bool { return resp.Checks[i].Name < resp.Checks[j].Name })
+
+	w.Header().Set("Content-Type", "application/json")
+	if !allOK {
+		resp.Status = "unavailable"
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+// handleInfo returns build metadata plus whatever each registered
+// InfoProvider contributes (enabled channels, enabled tools, a non-secret
+// config summary, ...).
+func (r *HealthRegistry) handleInfo(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	providers := make([]InfoProvider, len(r.info))
+	copy(providers, r.info)
+	r.mu.Unlock()
+
+	body := map[string]interface{}{
+		"version":   Version,
+		"commit":    Commit,
+		"goVersion": runtime.Version(),
+	}
+	for _, p := range providers {
+		body[p.InfoKey()] = p.Info()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(body)
+}
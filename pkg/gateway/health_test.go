@@ -0,0 +1,102 @@
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHealthRegistry_Healthz(t *testing.T) {
+	r := NewHealthRegistry()
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rec := httptest.NewRecorder()
+	r.handleHealthz(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+}
+
+func TestHealthRegistry_Readyz_AllPass(t *testing.T) {
+	r := NewHealthRegistry()
+	r.RegisterChecker(HealthCheckerFunc{CheckName: "session-store", Fn: func() error { return nil }})
+	r.RegisterChecker(HealthCheckerFunc{CheckName: "events", Fn: func() error { return nil }})
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rec := httptest.NewRecorder()
+	r.handleReadyz(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHealthRegistry_Readyz_ReportsFailures(t *testing.T) {
+	r := NewHealthRegistry()
+	r.RegisterChecker(HealthCheckerFunc{CheckName: "session-store", Fn: func() error { return nil }})
+	r.RegisterChecker(HealthCheckerFunc{CheckName: "channel:telegram", Fn: func() error { return errors.New("not connected") }})
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rec := httptest.NewRecorder()
+	r.handleReadyz(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", rec.Code)
+	}
+
+	var resp readyzResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Status != "unavailable" {
+		t.Errorf("expected status unavailable, got %q", resp.Status)
+	}
+
+	var failed *readyCheckResult
+	for i := range resp.Checks {
+		if resp.Checks[i].Name == "channel:telegram" {
+			failed = &resp.Checks[i]
+		}
+	}
+	if failed == nil || failed.Status != "failed" || failed.Error != "not connected" {
+		t.Fatalf("expected channel:telegram to be reported as failed, got %+v", resp.Checks)
+	}
+}
+
+type fakeInfoProvider struct {
+	key   string
+	value interface{}
+}
+
+func (f fakeInfoProvider) InfoKey() string   { return f.key }
+func (f fakeInfoProvider) Info() interface{} { return f.value }
+
+func TestHealthRegistry_Info(t *testing.T) {
+	r := NewHealthRegistry()
+	r.RegisterInfo(fakeInfoProvider{key: "channels", value: []string{"telegram"}})
+	r.RegisterInfo(fakeInfoProvider{key: "tools", value: []string{"web_fetch", "web_search"}})
+
+	req := httptest.NewRequest(http.MethodGet, "/info", nil)
+	rec := httptest.NewRecorder()
+	r.handleInfo(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if _, ok := body["version"]; !ok {
+		t.Error("expected version in /info response")
+	}
+	if _, ok := body["channels"]; !ok {
+		t.Error("expected channels in /info response")
+	}
+	if _, ok := body["tools"]; !ok {
+		t.Error("expected tools in /info response")
+	}
+}
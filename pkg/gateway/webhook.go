@@ -0,0 +1,312 @@
+// Package gateway implements picoclaw's HTTP server: the surface other
+// services use to reach agents (incoming webhooks, health checks, etc).
+package gateway
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yimiaoxiehou/picoclaw/pkg/config"
+)
+
+// InboundMessage is the synthetic message handed to an agent on behalf of
+// a channel that has no native transport for this request (e.g. a
+// third-party webhook). It's shaped so channel adapters can feed it through
+// the same path as a native incoming message.
+type InboundMessage struct {
+	Channel string
+	Target  string // channel-specific target, e.g. Telegram chat ID
+	Text    string
+	Raw     map[string]interface{}
+}
+
+// ChannelAdapter is the subset of a channel adapter the webhook subsystem
+// needs in order to inject a synthetic inbound message.
+type ChannelAdapter interface {
+	Name() string
+	HandleInbound(msg InboundMessage) error
+}
+
+// WebhookStore abstracts persistence of per-channel incoming-webhook
+// tokens, decoupling the HTTP handlers from how tokens are configured
+// (static config file today, a future admin API tomorrow).
+type WebhookStore interface {
+	// Lookup resolves a token to the channel it targets. ok is false if
+	// the token is unknown.
+	Lookup(token string) (channel string, cfg config.IncomingWebhookConfig, ok bool)
+}
+
+// configWebhookStore serves webhook tokens straight out of the static
+// config file (cfg.Channels.<name>.IncomingWebhooks).
+type configWebhookStore struct {
+	// byToken maps a webhook token to the channel name it's registered
+	// under, built once at startup.
+	byToken map[string]tokenEntry
+}
+
+type tokenEntry struct {
+	channel string
+	cfg     config.IncomingWebhookConfig
+}
+
+// NewConfigWebhookStore builds a WebhookStore from the static config.
+func NewConfigWebhookStore(cfg *config.Config) WebhookStore {
+	store := &configWebhookStore{byToken: make(map[string]tokenEntry)}
+
+	register := func(channel string, hooks map[string]config.IncomingWebhookConfig) {
+		for _, h := range hooks {
+			store.byToken[h.Token] = tokenEntry{channel: channel, cfg: h}
+		}
+	}
+	register("whatsapp", cfg.Channels.WhatsApp.IncomingWebhooks)
+	register("telegram", cfg.Channels.Telegram.IncomingWebhooks)
+	register("feishu", cfg.Channels.Feishu.IncomingWebhooks)
+	register("discord", cfg.Channels.Discord.IncomingWebhooks)
+	register("maixcam", cfg.Channels.MaixCam.IncomingWebhooks)
+	register("qq", cfg.Channels.QQ.IncomingWebhooks)
+	register("dingtalk", cfg.Channels.DingTalk.IncomingWebhooks)
+	register("slack", cfg.Channels.Slack.IncomingWebhooks)
+
+	return store
+}
+
+func (s *configWebhookStore) Lookup(token string) (string, config.IncomingWebhookConfig, bool) {
+	entry, ok := s.byToken[token]
+	if !ok {
+		return "", config.IncomingWebhookConfig{}, false
+	}
+	return entry.channel, entry.cfg, true
+}
+
+// rateLimiter enforces a simple fixed-window per-token request cap.
+type rateLimiter struct {
+	mu      sync.Mutex
+	windows map[string]*window
+}
+
+type window struct {
+	count   int
+	resetAt time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{windows: make(map[string]*window)}
+}
+
+// Allow reports whether token may make another request under limit
+// requests per minute. limit <= 0 means unlimited.
+func (r *rateLimiter) Allow(token string, limit int) bool {
+	if limit <= 0 {
+		return true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	w, ok := r.windows[token]
+	if !ok || now.After(w.resetAt) {
+		w = &window{count: 0, resetAt: now.Add(time.Minute)}
+		r.windows[token] = w
+	}
+	if w.count >= limit {
+		return false
+	}
+	w.count++
+	return true
+}
+
+// WebhookHandler serves the incoming-webhook subsystem: POST /hooks/{token}
+// and its Slack-compatible variant.
+type WebhookHandler struct {
+	store    WebhookStore
+	channels map[string]ChannelAdapter
+	limiter  *rateLimiter
+}
+
+// NewWebhookHandler builds a handler that resolves tokens via store and
+// dispatches accepted payloads to the given channel adapters, keyed by
+// ChannelAdapter.Name().
+func NewWebhookHandler(store WebhookStore, channels []ChannelAdapter) *WebhookHandler {
+	byName := make(map[string]ChannelAdapter, len(channels))
+	for _, c := range channels {
+		byName[c.Name()] = c
+	}
+	return &WebhookHandler{
+		store:    store,
+		channels: byName,
+		limiter:  newRateLimiter(),
+	}
+}
+
+// RegisterRoutes mounts the webhook endpoints on mux.
+func (h *WebhookHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/hooks/", h.handle)
+}
+
+// handle dispatches both POST /hooks/{token} and
+// POST /hooks/{token}/slack-compatible.
+func (h *WebhookHandler) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/hooks/")
+	slackCompatible := false
+	if strings.HasSuffix(path, "/slack-compatible") {
+		slackCompatible = true
+		path = strings.TrimSuffix(path, "/slack-compatible")
+	}
+	token := strings.Trim(path, "/")
+	if token == "" {
+		http.Error(w, "missing webhook token", http.StatusNotFound)
+		return
+	}
+
+	channelName, hookCfg, ok := h.store.Lookup(token)
+	if !ok {
+		http.Error(w, "unknown webhook token", http.StatusNotFound)
+		return
+	}
+
+	if !allowedSourceIP(r, hookCfg.AllowedIPs) {
+		http.Error(w, "source IP not allowed", http.StatusForbidden)
+		return
+	}
+
+	if !h.limiter.Allow(token, hookCfg.RateLimitPerMinute) {
+		http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	if hookCfg.Secret != "" {
+		if err := verifySignature(hookCfg.Secret, body, r.Header.Get("X-Signature-256")); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	adapter, ok := h.channels[channelName]
+	if !ok {
+		http.Error(w, fmt.Sprintf("channel %q not configured", channelName), http.StatusServiceUnavailable)
+		return
+	}
+
+	msg, err := parseInbound(channelName, body, slackCompatible)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := adapter.HandleInbound(msg); err != nil {
+		log.Printf("gateway: webhook %s: channel %s rejected inbound message: %v", token, channelName, err)
+		http.Error(w, "failed to dispatch message", http.StatusBadGateway)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	w.Write([]byte(`{"status":"ok"}`))
+}
+
+// allowedSourceIP reports whether r's remote address is permitted by
+// allowList. An empty allowList permits every source.
+func allowedSourceIP(r *http.Request, allowList []string) bool {
+	if len(allowList) == 0 {
+		return true
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, entry := range allowList {
+		if strings.Contains(entry, "/") {
+			_, cidr, err := net.ParseCIDR(entry)
+			if err == nil && cidr.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if entry == host {
+			return true
+		}
+	}
+	return false
+}
+
+// verifySignature validates the X-Signature-256 header, which carries
+// "sha256=<hex hmac>" over the raw request body.
+func verifySignature(secret string, body []byte, header string) error {
+	if header == "" {
+		return fmt.Errorf("missing X-Signature-256 header")
+	}
+	const prefix = "sha256="
+	if !strings.HasPrefix(header, prefix) {
+		return fmt.Errorf("unsupported signature format")
+	}
+	want, err := hex.DecodeString(strings.TrimPrefix(header, prefix))
+	if err != nil {
+		return fmt.Errorf("malformed signature")
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	got := mac.Sum(nil)
+
+	if !hmac.Equal(got, want) || subtle.ConstantTimeCompare(got, want) != 1 {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+// parseInbound turns a raw webhook payload into an InboundMessage. The
+// Slack-compatible shape is `{"channel": "...", "text": "..."}`; the native
+// shape is `{"target": "...", "text": "..."}`.
+func parseInbound(channel string, body []byte, slackCompatible bool) (InboundMessage, error) {
+	var payload struct {
+		Channel string `json:"channel"`
+		Target  string `json:"target"`
+		Text    string `json:"text"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return InboundMessage{}, fmt.Errorf("invalid JSON payload: %w", err)
+	}
+	if payload.Text == "" {
+		return InboundMessage{}, fmt.Errorf("text is required")
+	}
+
+	target := payload.Target
+	if slackCompatible && target == "" {
+		target = payload.Channel
+	}
+
+	raw := make(map[string]interface{})
+	json.Unmarshal(body, &raw)
+
+	return InboundMessage{
+		Channel: channel,
+		Target:  target,
+		Text:    payload.Text,
+		Raw:     raw,
+	}, nil
+}
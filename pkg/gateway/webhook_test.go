@@ -0,0 +1,151 @@
+package gateway
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yimiaoxiehou/picoclaw/pkg/config"
+)
+
+type fakeAdapter struct {
+	name     string
+	received []InboundMessage
+	err      error
+}
+
+func (f *fakeAdapter) Name() string { return f.name }
+
+func (f *fakeAdapter) HandleInbound(msg InboundMessage) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.received = append(f.received, msg)
+	return nil
+}
+
+func newTestHandler(hook config.IncomingWebhookConfig, adapter *fakeAdapter) *WebhookHandler {
+	cfg := config.DefaultConfig()
+	tg := cfg.Channels.Telegram
+	tg.IncomingWebhooks = map[string]config.IncomingWebhookConfig{"default": hook}
+	cfg.Channels.Telegram = tg
+
+	store := NewConfigWebhookStore(cfg)
+	return NewWebhookHandler(store, []ChannelAdapter{adapter})
+}
+
+func TestWebhookHandler_UnknownToken(t *testing.T) {
+	h := newTestHandler(config.IncomingWebhookConfig{Token: "good-token"}, &fakeAdapter{name: "telegram"})
+
+	req := httptest.NewRequest(http.MethodPost, "/hooks/bad-token", bytes.NewBufferString(`{"text":"hi"}`))
+	rec := httptest.NewRecorder()
+	h.handle(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown token, got %d", rec.Code)
+	}
+}
+
+func TestWebhookHandler_InjectsInboundMessage(t *testing.T) {
+	adapter := &fakeAdapter{name: "telegram"}
+	h := newTestHandler(config.IncomingWebhookConfig{Token: "good-token"}, adapter)
+
+	req := httptest.NewRequest(http.MethodPost, "/hooks/good-token", bytes.NewBufferString(`{"target":"123","text":"hello"}`))
+	rec := httptest.NewRecorder()
+	h.handle(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(adapter.received) != 1 || adapter.received[0].Text != "hello" {
+		t.Fatalf("expected adapter to receive the inbound message, got %+v", adapter.received)
+	}
+}
+
+func TestWebhookHandler_RejectsBadSignature(t *testing.T) {
+	adapter := &fakeAdapter{name: "telegram"}
+	h := newTestHandler(config.IncomingWebhookConfig{Token: "good-token", Secret: "shh"}, adapter)
+
+	req := httptest.NewRequest(http.MethodPost, "/hooks/good-token", bytes.NewBufferString(`{"text":"hello"}`))
+	req.Header.Set("X-Signature-256", "sha256=deadbeef")
+	rec := httptest.NewRecorder()
+	h.handle(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for bad signature, got %d", rec.Code)
+	}
+}
+
+func TestWebhookHandler_AcceptsValidSignature(t *testing.T) {
+	adapter := &fakeAdapter{name: "telegram"}
+	h := newTestHandler(config.IncomingWebhookConfig{Token: "good-token", Secret: "shh"}, adapter)
+
+	body := []byte(`{"text":"hello"}`)
+	mac := hmac.New(sha256.New, []byte("shh"))
+	mac.Write(body)
+	sig := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	req := httptest.NewRequest(http.MethodPost, "/hooks/good-token", bytes.NewBuffer(body))
+	req.Header.Set("X-Signature-256", sig)
+	rec := httptest.NewRecorder()
+	h.handle(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestWebhookHandler_RejectsDisallowedIP(t *testing.T) {
+	adapter := &fakeAdapter{name: "telegram"}
+	h := newTestHandler(config.IncomingWebhookConfig{Token: "good-token", AllowedIPs: []string{"10.0.0.1"}}, adapter)
+
+	req := httptest.NewRequest(http.MethodPost, "/hooks/good-token", bytes.NewBufferString(`{"text":"hello"}`))
+	req.RemoteAddr = "203.0.113.5:1234"
+	rec := httptest.NewRecorder()
+	h.handle(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for disallowed IP, got %d", rec.Code)
+	}
+}
+
+func TestWebhookHandler_EnforcesRateLimit(t *testing.T) {
+	adapter := &fakeAdapter{name: "telegram"}
+	h := newTestHandler(config.IncomingWebhookConfig{Token: "good-token", RateLimitPerMinute: 1}, adapter)
+
+	req := func() *http.Request {
+		return httptest.NewRequest(http.MethodPost, "/hooks/good-token", bytes.NewBufferString(`{"text":"hello"}`))
+	}
+
+	rec1 := httptest.NewRecorder()
+	h.handle(rec1, req())
+	if rec1.Code != http.StatusAccepted {
+		t.Fatalf("expected first request to succeed, got %d", rec1.Code)
+	}
+
+	rec2 := httptest.NewRecorder()
+	h.handle(rec2, req())
+	if rec2.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected second request to be rate limited, got %d", rec2.Code)
+	}
+}
+
+func TestWebhookHandler_SlackCompatiblePayload(t *testing.T) {
+	adapter := &fakeAdapter{name: "telegram"}
+	h := newTestHandler(config.IncomingWebhookConfig{Token: "good-token"}, adapter)
+
+	req := httptest.NewRequest(http.MethodPost, "/hooks/good-token/slack-compatible", bytes.NewBufferString(`{"channel":"C01234","text":"hi from slack format"}`))
+	rec := httptest.NewRecorder()
+	h.handle(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(adapter.received) != 1 || adapter.received[0].Target != "C01234" {
+		t.Fatalf("expected target resolved from slack-compatible channel field, got %+v", adapter.received)
+	}
+}
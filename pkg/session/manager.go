@@ -0,0 +1,112 @@
+package session
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/yimiaoxiehou/picoclaw/pkg/config"
+)
+
+// SessionManager is the in-process API agents and channel adapters use to
+// read and append to conversation history. Persistence is delegated to a
+// SessionStore so the backend (filesystem, SQLite, memory) can be swapped
+// without touching callers.
+type SessionManager struct {
+	mu    sync.RWMutex
+	store SessionStore
+	cache map[string]*Session
+}
+
+// NewSessionManager returns a SessionManager backed by the filesystem, one
+// JSON file per key under dir. This is picoclaw's original, dependency-free
+// backend and remains the default.
+func NewSessionManager(dir string) *SessionManager {
+	return NewSessionManagerWithStore(NewFileStore(dir))
+}
+
+// NewSessionManagerWithStore returns a SessionManager backed by an
+// arbitrary SessionStore.
+func NewSessionManagerWithStore(store SessionStore) *SessionManager {
+	return &SessionManager{store: store, cache: make(map[string]*Session)}
+}
+
+// NewSessionManagerFromConfig selects a backend based on cfg.Sessions.Backend
+// ("file", "sqlite" or "memory"; defaults to "file").
+func NewSessionManagerFromConfig(cfg *config.SessionsConfig) (*SessionManager, error) {
+	switch cfg.Backend {
+	case "", "file":
+		return NewSessionManager(cfg.Dir), nil
+	case "sqlite":
+		path := cfg.SQLitePath
+		if path == "" {
+			path = filepath.Join(cfg.Dir, "sessions.db")
+		}
+		store, err := NewSQLiteStore(path)
+		if err != nil {
+			return nil, err
+		}
+		return NewSessionManagerWithStore(store), nil
+	case "memory":
+		return NewSessionManagerWithStore(NewMemoryStore()), nil
+	default:
+		return nil, fmt.Errorf("session: unknown backend %q", cfg.Backend)
+	}
+}
+
+// GetOrCreate loads key's session (creating an empty one if it doesn't
+// exist yet) and returns it, caching it in memory for subsequent calls.
+func (m *SessionManager) GetOrCreate(key string) *Session {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if sess, ok := m.cache[key]; ok {
+		return sess
+	}
+
+	sess, err := m.store.Load(key)
+	if err != nil {
+		sess = &Session{Key: key}
+	}
+	m.cache[key] = sess
+	return sess
+}
+
+// AddMessage appends a message to key's in-memory session and persists it
+// immediately via the store's AppendMessage, so backends like SQLite can
+// write a single row instead of requiring a full-session Save.
+func (m *SessionManager) AddMessage(key, role, content string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sess, ok := m.cache[key]
+	if !ok {
+		loaded, err := m.store.Load(key)
+		if err != nil {
+			loaded = &Session{Key: key}
+		}
+		sess = loaded
+		m.cache[key] = sess
+	}
+	msg := Message{Role: role, Content: content, Timestamp: time.Now()}
+	sess.Messages = append(sess.Messages, msg)
+	return m.store.AppendMessage(key, msg)
+}
+
+// Save persists key's in-memory session via the configured store.
+func (m *SessionManager) Save(key string) error {
+	m.mu.RLock()
+	sess, ok := m.cache[key]
+	m.mu.RUnlock()
+	if !ok {
+		sess = &Session{Key: key}
+	}
+	return m.store.Save(key, sess)
+}
+
+// GetHistory returns the messages recorded for key, loading from the
+// store if it isn't already cached.
+func (m *SessionManager) GetHistory(key string) []Message {
+	return m.GetOrCreate(key).Messages
+}
@@ -0,0 +1,53 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MigrateFileStoreTo copies every session from the legacy one-JSON-file-per-key
+// layout at dir into dst. It's meant to be run once, offline, when switching
+// an existing deployment's cfg.Sessions.Backend away from "file". The source
+// files are left untouched.
+func MigrateFileStoreTo(dir string, dst SessionStore) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("session: migrate: reading %s: %w", dir, err)
+	}
+
+	migrated := 0
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+
+		path := filepath.Join(dir, e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return migrated, fmt.Errorf("session: migrate: reading %s: %w", path, err)
+		}
+
+		var sess Session
+		if err := json.Unmarshal(data, &sess); err != nil {
+			return migrated, fmt.Errorf("session: migrate: parsing %s: %w", path, err)
+		}
+
+		key := sess.Key
+		if key == "" {
+			key = strings.TrimSuffix(e.Name(), ".json")
+		}
+
+		if err := dst.Save(key, &sess); err != nil {
+			return migrated, fmt.Errorf("session: migrate: writing %q to new backend: %w", key, err)
+		}
+		migrated++
+	}
+
+	return migrated, nil
+}
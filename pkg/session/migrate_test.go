@@ -0,0 +1,46 @@
+package session
+
+import (
+	"testing"
+)
+
+func TestMigrateFileStoreTo_CopiesExistingSessions(t *testing.T) {
+	tmpDir := t.TempDir()
+	sm := NewSessionManager(tmpDir)
+
+	sm.GetOrCreate("telegram:123456")
+	sm.AddMessage("telegram:123456", "user", "hello")
+	if err := sm.Save("telegram:123456"); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	dst := NewMemoryStore()
+	n, err := MigrateFileStoreTo(tmpDir, dst)
+	if err != nil {
+		t.Fatalf("migration failed: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("expected 1 migrated session, got %d", n)
+	}
+
+	sess, err := dst.Load("telegram:123456")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(sess.Messages) != 1 || sess.Messages[0].Content != "hello" {
+		t.Fatalf("expected migrated message to round-trip, got %+v", sess.Messages)
+	}
+}
+
+func TestMigrateFileStoreTo_EmptyDirIsNoop(t *testing.T) {
+	tmpDir := t.TempDir()
+	dst := NewMemoryStore()
+
+	n, err := MigrateFileStoreTo(tmpDir, dst)
+	if err != nil {
+		t.Fatalf("migration of empty dir should not fail: %v", err)
+	}
+	if n != 0 {
+		t.Fatalf("expected 0 migrated sessions, got %d", n)
+	}
+}
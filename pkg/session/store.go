@@ -0,0 +1,38 @@
+// Package session manages per-conversation chat history, keyed by a
+// channel-specific string such as "telegram:123456".
+package session
+
+import "time"
+
+// Message is a single turn in a session's history.
+type Message struct {
+	Role      string    `json:"role"`
+	Content   string    `json:"content"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Session is the full history addressed by a single key.
+type Session struct {
+	Key      string    `json:"key"`
+	Messages []Message `json:"messages"`
+}
+
+// SessionStore persists sessions. Implementations decide how: one JSON
+// file per key, one SQLite row per message, or purely in memory for
+// tests. The manager only ever talks to this interface, so backends can
+// be swapped per deployment via config.
+type SessionStore interface {
+	// Load returns the session for key, or an empty session if none
+	// exists yet.
+	Load(key string) (*Session, error)
+	// AppendMessage persists a single new message for key without
+	// requiring the full session to be rewritten.
+	AppendMessage(key string, msg Message) error
+	// Save persists the full session, overwriting whatever is stored for
+	// key.
+	Save(key string, s *Session) error
+	// List returns every key with a stored session.
+	List() ([]string, error)
+	// Delete removes the session stored under key, if any.
+	Delete(key string) error
+}
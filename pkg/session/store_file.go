@@ -0,0 +1,142 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// FileStore is the original session backend: one JSON file per sanitized
+// key under dir. It's simple and dependency-free, but every AppendMessage
+// rewrites the whole file and concurrent writers to the same key can race.
+type FileStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFileStore returns a FileStore rooted at dir. dir is created lazily on
+// first write.
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+// sanitizeFilename replaces characters that are awkward or unsafe in a
+// filename (":" from channel keys like "telegram:123456") with "_".
+func sanitizeFilename(key string) string {
+	return strings.ReplaceAll(key, ":", "_")
+}
+
+// pathFor validates key and returns the on-disk path for its session
+// file. It rejects empty keys, ".", ".." and any key containing a path
+// separator, since those could otherwise escape dir.
+func (s *FileStore) pathFor(key string) (string, error) {
+	if key == "" || key == "." || key == ".." {
+		return "", fmt.Errorf("session: invalid key %q", key)
+	}
+	if strings.ContainsAny(key, "/\\") {
+		return "", fmt.Errorf("session: key %q must not contain path separators", key)
+	}
+	return filepath.Join(s.dir, sanitizeFilename(key)+".json"), nil
+}
+
+func (s *FileStore) Load(key string) (*Session, error) {
+	path, err := s.pathFor(key)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Session{Key: key}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var sess Session
+	if err := json.Unmarshal(data, &sess); err != nil {
+		return nil, fmt.Errorf("session: corrupt file %s: %w", path, err)
+	}
+	return &sess, nil
+}
+
+func (s *FileStore) AppendMessage(key string, msg Message) error {
+	sess, err := s.Load(key)
+	if err != nil {
+		return err
+	}
+	sess.Messages = append(sess.Messages, msg)
+	return s.Save(key, sess)
+}
+
+func (s *FileStore) Save(key string, sess *Session) error {
+	path, err := s.pathFor(key)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(sess, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func (s *FileStore) List() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var sess Session
+		if err := json.Unmarshal(data, &sess); err != nil {
+			return nil, fmt.Errorf("session: corrupt file %s: %w", e.Name(), err)
+		}
+		keys = append(keys, sess.Key)
+	}
+	return keys, nil
+}
+
+func (s *FileStore) Delete(key string) error {
+	path, err := s.pathFor(key)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
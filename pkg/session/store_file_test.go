@@ -0,0 +1,21 @@
+package session
+
+import "testing"
+
+func TestFileStore_List_ReturnsRealKeyNotSanitizedFilename(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := NewFileStore(tmpDir)
+
+	key := "telegram:123456"
+	if err := store.Save(key, &Session{Key: key}); err != nil {
+		t.Fatalf("Save(%q) failed: %v", key, err)
+	}
+
+	keys, err := store.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(keys) != 1 || keys[0] != key {
+		t.Fatalf("expected List to return the real key %q, got %v", key, keys)
+	}
+}
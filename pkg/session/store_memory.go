@@ -0,0 +1,69 @@
+package session
+
+import "sync"
+
+// MemoryStore is a non-persistent SessionStore, useful for tests and for
+// ephemeral deployments that don't want history surviving a restart.
+type MemoryStore struct {
+	mu       sync.Mutex
+	sessions map[string]*Session
+}
+
+// NewMemoryStore returns an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{sessions: make(map[string]*Session)}
+}
+
+func (s *MemoryStore) Load(key string) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sess, ok := s.sessions[key]; ok {
+		clone := *sess
+		clone.Messages = append([]Message(nil), sess.Messages...)
+		return &clone, nil
+	}
+	return &Session{Key: key}, nil
+}
+
+func (s *MemoryStore) AppendMessage(key string, msg Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess, ok := s.sessions[key]
+	if !ok {
+		sess = &Session{Key: key}
+		s.sessions[key] = sess
+	}
+	sess.Messages = append(sess.Messages, msg)
+	return nil
+}
+
+func (s *MemoryStore) Save(key string, sess *Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clone := *sess
+	clone.Messages = append([]Message(nil), sess.Messages...)
+	s.sessions[key] = &clone
+	return nil
+}
+
+func (s *MemoryStore) List() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]string, 0, len(s.sessions))
+	for k := range s.sessions {
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+func (s *MemoryStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, key)
+	return nil
+}
@@ -0,0 +1,135 @@
+package session
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore persists sessions as one row per message, indexed by key and
+// timestamp, so appending a message never requires rewriting history.
+// Unlike FileStore it stores the raw key: sanitizeFilename's
+// path-traversal concerns don't apply to a database row.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens (and migrates, if needed) the database at path.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("session: opening sqlite store: %w", err)
+	}
+
+	const schema = `
+CREATE TABLE IF NOT EXISTS messages (
+	id        INTEGER PRIMARY KEY AUTOINCREMENT,
+	key       TEXT NOT NULL,
+	role      TEXT NOT NULL,
+	content   TEXT NOT NULL,
+	timestamp DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_messages_key_timestamp ON messages(key, timestamp);
+`
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("session: migrating sqlite store: %w", err)
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+// Close releases the underlying database handle.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteStore) Load(key string) (*Session, error) {
+	rows, err := s.db.Query(
+		`SELECT role, content, timestamp FROM messages WHERE key = ? ORDER BY timestamp ASC, id ASC`, key)
+	if err != nil {
+		return nil, fmt.Errorf("session: loading %q: %w", key, err)
+	}
+	defer rows.Close()
+
+	sess := &Session{Key: key}
+	for rows.Next() {
+		var msg Message
+		if err := rows.Scan(&msg.Role, &msg.Content, &msg.Timestamp); err != nil {
+			return nil, fmt.Errorf("session: scanning %q: %w", key, err)
+		}
+		sess.Messages = append(sess.Messages, msg)
+	}
+	return sess, rows.Err()
+}
+
+func (s *SQLiteStore) AppendMessage(key string, msg Message) error {
+	if msg.Timestamp.IsZero() {
+		msg.Timestamp = time.Now()
+	}
+	_, err := s.db.Exec(
+		`INSERT INTO messages (key, role, content, timestamp) VALUES (?, ?, ?, ?)`,
+		key, msg.Role, msg.Content, msg.Timestamp)
+	if err != nil {
+		return fmt.Errorf("session: appending message for %q: %w", key, err)
+	}
+	return nil
+}
+
+// Save replaces every message stored for key with sess.Messages, atomically.
+func (s *SQLiteStore) Save(key string, sess *Session) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("session: saving %q: %w", key, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM messages WHERE key = ?`, key); err != nil {
+		return fmt.Errorf("session: saving %q: %w", key, err)
+	}
+
+	stmt, err := tx.Prepare(`INSERT INTO messages (key, role, content, timestamp) VALUES (?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("session: saving %q: %w", key, err)
+	}
+	defer stmt.Close()
+
+	for _, msg := range sess.Messages {
+		ts := msg.Timestamp
+		if ts.IsZero() {
+			ts = time.Now()
+		}
+		if _, err := stmt.Exec(key, msg.Role, msg.Content, ts); err != nil {
+			return fmt.Errorf("session: saving %q: %w", key, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *SQLiteStore) List() ([]string, error) {
+	rows, err := s.db.Query(`SELECT DISTINCT key FROM messages`)
+	if err != nil {
+		return nil, fmt.Errorf("session: listing sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("session: listing sessions: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+func (s *SQLiteStore) Delete(key string) error {
+	if _, err := s.db.Exec(`DELETE FROM messages WHERE key = ?`, key); err != nil {
+		return fmt.Errorf("session: deleting %q: %w", key, err)
+	}
+	return nil
+}
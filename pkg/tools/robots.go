@@ -0,0 +1,276 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// robotsRuleSet is one User-agent group's Allow/Disallow rules plus an
+// optional Crawl-delay, as parsed from a robots.txt.
+type robotsRuleSet struct {
+	allow      []string
+	disallow   []string
+	crawlDelay time.Duration
+}
+
+// robotsFile is a parsed robots.txt, grouped by the User-agent token it
+// was declared under ("*" for the wildcard group).
+type robotsFile struct {
+	groups map[string]*robotsRuleSet
+}
+
+// parseRobotsTxt parses the User-agent/Allow/Disallow/Crawl-delay
+// directives of a robots.txt body. Unknown directives are ignored.
+func parseRobotsTxt(body string) *robotsFile {
+	rf := &robotsFile{groups: make(map[string]*robotsRuleSet)}
+
+	var current []*robotsRuleSet
+	newGroup := true
+
+	getOrCreate := func(agent string) *robotsRuleSet {
+		if rs, ok := rf.groups[agent]; ok {
+			return rs
+		}
+		rs := &robotsRuleSet{}
+		rf.groups[agent] = rs
+		return rs
+	}
+
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			newGroup = true
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		field := strings.ToLower(strings.TrimSpace(parts[0]))
+		value := strings.TrimSpace(parts[1])
+
+		switch field {
+		case "user-agent":
+			if !newGroup {
+				// Still in a contiguous User-agent block: extra agents
+				// that share the directives following them.
+				current = append(current, getOrCreate(strings.ToLower(value)))
+				continue
+			}
+			current = []*robotsRuleSet{getOrCreate(strings.ToLower(value))}
+			newGroup = false
+		case "allow":
+			newGroup = true
+			for _, rs := range current {
+				rs.allow = append(rs.allow, value)
+			}
+		case "disallow":
+			newGroup = true
+			if value == "" {
+				continue
+			}
+			for _, rs := range current {
+				rs.disallow = append(rs.disallow, value)
+			}
+		case "crawl-delay":
+			newGroup = true
+			if secs, err := strconv.ParseFloat(value, 64); err == nil {
+				d := time.Duration(secs * float64(time.Second))
+				for _, rs := range current {
+					rs.crawlDelay = d
+				}
+			}
+		}
+	}
+
+	return rf
+}
+
+// groupFor returns the most specific rule set for userAgent: the matching
+// agent token with the longest length, falling back to the wildcard "*"
+// group. It returns nil if neither is present.
+func (rf *robotsFile) groupFor(userAgent string) *robotsRuleSet {
+	ua := strings.ToLower(userAgent)
+
+	var best *robotsRuleSet
+	bestLen := -1
+	for agent, rs := range rf.groups {
+		if agent == "" || agent == "*" || !strings.Contains(ua, agent) {
+			continue
+		}
+		if len(agent) > bestLen {
+			bestLen = len(agent)
+			best = rs
+		}
+	}
+	if best != nil {
+		return best
+	}
+	if rs, ok := rf.groups["*"]; ok {
+		return rs
+	}
+	return nil
+}
+
+// allows reports whether path is permitted for userAgent, using
+// longest-match precedence between the matching Allow and Disallow rules.
+func (rf *robotsFile) allows(userAgent, path string) (bool, string) {
+	rs := rf.groupFor(userAgent)
+	if rs == nil {
+		return true, ""
+	}
+
+	bestLen := -1
+	bestAllow := true
+	bestRule := ""
+
+	check := func(rules []string, allow bool, minLen func(int) bool) {
+		for _, rule := range rules {
+			if rule == "" {
+				continue
+			}
+			if strings.HasPrefix(path, rule) && minLen(len(rule)) {
+				bestLen = len(rule)
+				bestAllow = allow
+				bestRule = rule
+			}
+		}
+	}
+	// Allow checked last with ">=" so it wins ties against an
+	// equal-length Disallow, per Google's robots.txt spec.
+	check(rs.disallow, false, func(n int) bool { return n > bestLen })
+	check(rs.allow, true, func(n int) bool { return n >= bestLen })
+
+	if bestLen < 0 {
+		return true, ""
+	}
+	return bestAllow, bestRule
+}
+
+// crawlDelay returns the Crawl-delay declared for userAgent, or 0 if none.
+func (rf *robotsFile) crawlDelay(userAgent string) time.Duration {
+	rs := rf.groupFor(userAgent)
+	if rs == nil {
+		return 0
+	}
+	return rs.crawlDelay
+}
+
+type robotsCacheEntry struct {
+	file      *robotsFile
+	fetchedAt time.Time
+}
+
+// RobotsChecker fetches and caches robots.txt per host, and enforces each
+// host's declared Crawl-delay as a minimum interval between successive
+// fetches the tool makes to that host.
+type RobotsChecker struct {
+	client    *http.Client
+	userAgent string
+	ttl       time.Duration
+
+	mu          sync.Mutex
+	cache       map[string]robotsCacheEntry
+	lastFetchAt map[string]time.Time
+}
+
+// NewRobotsChecker returns a checker that caches each host's robots.txt
+// for ttl before re-fetching it.
+func NewRobotsChecker(client *http.Client, userAgent string, ttl time.Duration) *RobotsChecker {
+	return &RobotsChecker{
+		client:      client,
+		userAgent:   userAgent,
+		ttl:         ttl,
+		cache:       make(map[string]robotsCacheEntry),
+		lastFetchAt: make(map[string]time.Time),
+	}
+}
+
+// Allowed reports whether fetching target is permitted by its host's
+// robots.txt, blocking for any Crawl-delay owed to that host. On error
+// fetching robots.txt, it fails open (permits the fetch) since an
+// unreachable robots.txt shouldn't block a legitimate fetch.
+func (c *RobotsChecker) Allowed(ctx context.Context, target *url.URL) (bool, string) {
+	rf, err := c.robotsFor(ctx, target)
+	if err != nil {
+		return true, ""
+	}
+
+	allowed, rule := rf.allows(c.userAgent, target.Path)
+
+	if delay := rf.crawlDelay(c.userAgent); delay > 0 {
+		c.waitCrawlDelay(target.Host, delay)
+	}
+
+	if !allowed {
+		return false, fmt.Sprintf("disallowed by robots.txt rule %q for %s", rule, target.Host)
+	}
+	return true, ""
+}
+
+func (c *RobotsChecker) waitCrawlDelay(host string, delay time.Duration) {
+	c.mu.Lock()
+	now := time.Now()
+	var wait time.Duration
+	if last, ok := c.lastFetchAt[host]; ok {
+		wait = delay - now.Sub(last)
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	// Record when this fetch will actually go out, so the next one is
+	// spaced from it rather than from when this call arrived.
+	c.lastFetchAt[host] = now.Add(wait)
+	c.mu.Unlock()
+
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+func (c *RobotsChecker) robotsFor(ctx context.Context, target *url.URL) (*robotsFile, error) {
+	c.mu.Lock()
+	entry, ok := c.cache[target.Host]
+	c.mu.Unlock()
+	if ok && time.Since(entry.fetchedAt) < c.ttl {
+		return entry.file, nil
+	}
+
+	robotsURL := &url.URL{Scheme: target.Scheme, Host: target.Host, Path: "/robots.txt"}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// A robots.txt that 404s (or errors) means "no restrictions".
+	var rf *robotsFile
+	if resp.StatusCode == http.StatusOK {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		if err != nil {
+			return nil, err
+		}
+		rf = parseRobotsTxt(string(body))
+	} else {
+		rf = &robotsFile{groups: make(map[string]*robotsRuleSet)}
+	}
+
+	c.mu.Lock()
+	c.cache[target.Host] = robotsCacheEntry{file: rf, fetchedAt: time.Now()}
+	c.mu.Unlock()
+
+	return rf, nil
+}
@@ -0,0 +1,167 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRobotsTxt_AllowDisallowLongestMatch(t *testing.T) {
+	rf := parseRobotsTxt(`
+User-agent: *
+Disallow: /private/
+Allow: /private/public-page
+`)
+
+	allowed, _ := rf.allows("picoclaw/dev", "/private/public-page")
+	if !allowed {
+		t.Error("expected the longer Allow match to win over the shorter Disallow")
+	}
+
+	allowed, reason := rf.allows("picoclaw/dev", "/private/secret")
+	if allowed {
+		t.Errorf("expected /private/secret to be disallowed, reason: %q", reason)
+	}
+}
+
+func TestParseRobotsTxt_EqualLengthAllowBeatsDisallow(t *testing.T) {
+	rf := parseRobotsTxt(`
+User-agent: *
+Disallow: /x
+Allow: /x
+`)
+
+	allowed, reason := rf.allows("picoclaw/dev", "/x")
+	if !allowed {
+		t.Errorf("expected Allow to win an equal-length tie against Disallow, reason: %q", reason)
+	}
+}
+
+func TestParseRobotsTxt_GroupForPicksLongestMatchingAgent(t *testing.T) {
+	rf := parseRobotsTxt(`
+User-agent: bot
+Disallow: /
+
+User-agent: picoclaw-bot
+Allow: /
+`)
+
+	allowed, _ := rf.allows("picoclaw-bot/dev", "/anything")
+	if !allowed {
+		t.Error("expected the longest matching agent token (\"picoclaw-bot\") to win over the shorter substring match (\"bot\")")
+	}
+}
+
+func TestParseRobotsTxt_SpecificAgentBeatsWildcard(t *testing.T) {
+	rf := parseRobotsTxt(`
+User-agent: *
+Disallow: /
+
+User-agent: picoclaw
+Allow: /
+`)
+
+	allowed, _ := rf.allows("picoclaw/dev (+https://example.com)", "/anything")
+	if !allowed {
+		t.Error("expected the specific picoclaw group to override the wildcard Disallow")
+	}
+
+	allowed, _ = rf.allows("some-other-bot", "/anything")
+	if allowed {
+		t.Error("expected an unmatched agent to fall back to the wildcard Disallow")
+	}
+}
+
+func TestParseRobotsTxt_CrawlDelay(t *testing.T) {
+	rf := parseRobotsTxt(`
+User-agent: *
+Crawl-delay: 2
+`)
+
+	if got := rf.crawlDelay("picoclaw/dev"); got.Seconds() != 2 {
+		t.Errorf("expected crawl delay of 2s, got %v", got)
+	}
+}
+
+func TestWebFetchTool_RespectsRobotsDisallow(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("User-agent: *\nDisallow: /private/\n"))
+	})
+	mux.HandleFunc("/private/secret", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("should not be served"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tool := NewWebFetchTool(1000)
+	ctx := context.Background()
+
+	result := tool.Execute(ctx, map[string]interface{}{"url": server.URL + "/private/secret"})
+	if !result.IsError {
+		t.Fatalf("expected robots.txt to block the fetch, got: %+v", result)
+	}
+	if !strings.Contains(result.ForLLM, "robots.txt") {
+		t.Errorf("expected error to mention robots.txt, got: %s", result.ForLLM)
+	}
+}
+
+func TestWebFetchTool_BypassFlagOverridesRobots(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("User-agent: *\nDisallow: /private/\n"))
+	})
+	mux.HandleFunc("/private/secret", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tool := NewWebFetchTool(1000)
+	ctx := context.Background()
+
+	result := tool.Execute(ctx, map[string]interface{}{
+		"url":            server.URL + "/private/secret",
+		"respect_robots": false,
+	})
+	if result.IsError {
+		t.Fatalf("expected bypass flag to allow the fetch, got: %+v", result)
+	}
+}
+
+func TestWebFetchTool_SendsConfiguredUserAgent(t *testing.T) {
+	var gotUA string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		gotUA = r.Header.Get("User-Agent")
+		w.Write([]byte("hi"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tool := newWebFetchTool(1000, "my-custom-agent/1.0", true, time.Hour)
+	result := tool.Execute(context.Background(), map[string]interface{}{"url": server.URL + "/page"})
+
+	if result.IsError {
+		t.Fatalf("expected success, got: %+v", result)
+	}
+	if gotUA != "my-custom-agent/1.0" {
+		t.Errorf("expected configured User-Agent to be sent, got %q", gotUA)
+	}
+}
+
+func TestRobotsChecker_AllowsWhenRobotsUnreachable(t *testing.T) {
+	checker := NewRobotsChecker(&http.Client{}, "picoclaw/dev", 0)
+	target, _ := url.Parse("http://127.0.0.1:1/unreachable")
+	allowed, _ := checker.Allowed(context.Background(), target)
+	if !allowed {
+		t.Error("expected checker to fail open when robots.txt can't be fetched")
+	}
+}
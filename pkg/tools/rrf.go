@@ -0,0 +1,91 @@
+package tools
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// rrfK is the Reciprocal Rank Fusion smoothing constant. 60 is the value
+// most commonly used in IR literature and keeps a single low rank from
+// dominating the score.
+const rrfK = 60
+
+// fusedResult is a searchResult plus the providers that surfaced it and
+// its fused RRF score.
+type fusedResult struct {
+	searchResult
+	Sources []string
+	score   float64
+}
+
+// normalizeSearchURL canonicalizes a URL for cross-provider dedup:
+// lowercase host, strip utm_* query params and the fragment, collapse a
+// trailing slash.
+func normalizeSearchURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return strings.ToLower(raw)
+	}
+
+	u.Host = strings.ToLower(u.Host)
+	u.Fragment = ""
+
+	if u.RawQuery != "" {
+		q := u.Query()
+		for key := range q {
+			if strings.HasPrefix(strings.ToLower(key), "utm_") {
+				q.Del(key)
+			}
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	u.Path = strings.TrimSuffix(u.Path, "/")
+
+	return u.String()
+}
+
+// providerResults is one provider's ranked result list, ready for fusion.
+type providerResults struct {
+	provider string
+	results  []searchResult
+}
+
+// fuseRRF merges ranked result lists from multiple providers using
+// Reciprocal Rank Fusion: s(url) = sum over providers of 1/(k + rank),
+// where rank is 1-based. Results are deduplicated by normalized URL,
+// keeping the first-seen title/snippet and recording every provider that
+// surfaced it. The merged list is sorted by descending score and capped
+// to maxResults. perProvider's order determines which provider's
+// title/snippet wins a dedup collision (first seen wins).
+func fuseRRF(perProvider []providerResults, maxResults int) []fusedResult {
+	byURL := make(map[string]*fusedResult)
+	var order []string
+
+	for _, pr := range perProvider {
+		for rank, r := range pr.results {
+			key := normalizeSearchURL(r.URL)
+			fr, ok := byURL[key]
+			if !ok {
+				fr = &fusedResult{searchResult: r}
+				byURL[key] = fr
+				order = append(order, key)
+			}
+			fr.score += 1.0 / float64(rrfK+rank+1)
+			fr.Sources = append(fr.Sources, pr.provider)
+		}
+	}
+
+	merged := make([]fusedResult, 0, len(order))
+	for _, key := range order {
+		merged = append(merged, *byURL[key])
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].score > merged[j].score })
+
+	if len(merged) > maxResults {
+		merged = merged[:maxResults]
+	}
+	return merged
+}
@@ -0,0 +1,252 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// searchResult is one hit from a search provider, before it's formatted
+// for display. It's the shared shape RRF fusion merges across providers.
+type searchResult struct {
+	Title   string
+	URL     string
+	Snippet string
+}
+
+// structuredSearchProvider is implemented by every search provider so
+// WebSearchTool can run Reciprocal Rank Fusion across several of them.
+// Search (below) remains the single-provider, pre-formatted entry point
+// used when only one provider is enabled.
+type structuredSearchProvider interface {
+	providerName() string
+	searchStructured(ctx context.Context, query string, maxResults int) ([]searchResult, error)
+}
+
+// formatResults renders results as the numbered, human-readable block
+// every provider's Search returns.
+func formatResults(query, via string, results []searchResult) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Results for: %s (via %s)\n\n", query, via)
+	for i, r := range results {
+		fmt.Fprintf(&b, "%d. %s\n%s\n", i+1, r.Title, r.URL)
+		if r.Snippet != "" {
+			fmt.Fprintf(&b, "%s\n", r.Snippet)
+		}
+		if i != len(results)-1 {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// --- Brave ---
+
+// BraveSearchProvider queries the Brave Search API.
+type BraveSearchProvider struct {
+	apiKey   string
+	endpoint string
+}
+
+// NewBraveSearchProvider returns a provider using the Brave Search API.
+func NewBraveSearchProvider(apiKey string) *BraveSearchProvider {
+	return &BraveSearchProvider{apiKey: apiKey, endpoint: "https://api.search.brave.com/res/v1/web/search"}
+}
+
+func (p *BraveSearchProvider) providerName() string { return "Brave" }
+
+func (p *BraveSearchProvider) Search(ctx context.Context, query string, maxResults int) (string, error) {
+	results, err := p.searchStructured(ctx, query, maxResults)
+	if err != nil {
+		return "", err
+	}
+	return formatResults(query, "Brave", results), nil
+}
+
+func (p *BraveSearchProvider) searchStructured(ctx context.Context, query string, maxResults int) ([]searchResult, error) {
+	reqURL := p.endpoint + "?q=" + url.QueryEscape(query) + "&count=" + strconv.Itoa(maxResults)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Subscription-Token", p.apiKey)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("brave search: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("brave search: status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var payload struct {
+		Web struct {
+			Results []struct {
+				Title       string `json:"title"`
+				URL         string `json:"url"`
+				Description string `json:"description"`
+			} `json:"results"`
+		} `json:"web"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("brave search: decoding response: %w", err)
+	}
+
+	var results []searchResult
+	for _, r := range payload.Web.Results {
+		results = append(results, searchResult{Title: r.Title, URL: r.URL, Snippet: r.Description})
+		if len(results) >= maxResults {
+			break
+		}
+	}
+	return results, nil
+}
+
+// --- Exa ---
+
+// ExaSearchProvider queries the Exa search API.
+type ExaSearchProvider struct {
+	apiKey   string
+	endpoint string
+}
+
+// NewExaSearchProvider returns a provider using the Exa search API.
+func NewExaSearchProvider(apiKey string) *ExaSearchProvider {
+	return &ExaSearchProvider{apiKey: apiKey, endpoint: "https://api.exa.ai/search"}
+}
+
+func (p *ExaSearchProvider) providerName() string { return "Exa" }
+
+func (p *ExaSearchProvider) Search(ctx context.Context, query string, maxResults int) (string, error) {
+	results, err := p.searchStructured(ctx, query, maxResults)
+	if err != nil {
+		return "", err
+	}
+	return formatResults(query, "Exa", results), nil
+}
+
+func (p *ExaSearchProvider) searchStructured(ctx context.Context, query string, maxResults int) ([]searchResult, error) {
+	payload, _ := json.Marshal(map[string]interface{}{
+		"query":      query,
+		"type":       "auto",
+		"numResults": maxResults,
+	})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, strings.NewReader(string(payload)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-api-key", p.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("exa search: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("exa search: status %d: %s", resp.StatusCode, exaErrorMessage(body))
+	}
+
+	var decoded struct {
+		Results []struct {
+			Title string `json:"title"`
+			URL   string `json:"url"`
+			Text  string `json:"text"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return nil, fmt.Errorf("exa search: decoding response: %w", err)
+	}
+
+	var results []searchResult
+	for _, r := range decoded.Results {
+		results = append(results, searchResult{Title: r.Title, URL: r.URL, Snippet: r.Text})
+	}
+	return results, nil
+}
+
+func exaErrorMessage(body []byte) string {
+	var payload struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil && payload.Error != "" {
+		return payload.Error
+	}
+	return string(body)
+}
+
+// --- DuckDuckGo ---
+
+// DuckDuckGoSearchProvider queries DuckDuckGo's keyless instant-answer API.
+type DuckDuckGoSearchProvider struct {
+	endpoint string
+}
+
+// NewDuckDuckGoSearchProvider returns a provider using DuckDuckGo's
+// instant-answer API, which requires no API key.
+func NewDuckDuckGoSearchProvider() *DuckDuckGoSearchProvider {
+	return &DuckDuckGoSearchProvider{endpoint: "https://api.duckduckgo.com/"}
+}
+
+func (p *DuckDuckGoSearchProvider) providerName() string { return "DuckDuckGo" }
+
+func (p *DuckDuckGoSearchProvider) Search(ctx context.Context, query string, maxResults int) (string, error) {
+	results, err := p.searchStructured(ctx, query, maxResults)
+	if err != nil {
+		return "", err
+	}
+	return formatResults(query, "DuckDuckGo", results), nil
+}
+
+func (p *DuckDuckGoSearchProvider) searchStructured(ctx context.Context, query string, maxResults int) ([]searchResult, error) {
+	reqURL := p.endpoint + "?q=" + url.QueryEscape(query) + "&format=json&no_html=1"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("duckduckgo search: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("duckduckgo search: status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var payload struct {
+		RelatedTopics []struct {
+			Text     string `json:"Text"`
+			FirstURL string `json:"FirstURL"`
+		} `json:"RelatedTopics"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("duckduckgo search: decoding response: %w", err)
+	}
+
+	var results []searchResult
+	for _, t := range payload.RelatedTopics {
+		if t.FirstURL == "" {
+			continue
+		}
+		results = append(results, searchResult{Title: t.Text, URL: t.FirstURL, Snippet: t.Text})
+		if len(results) >= maxResults {
+			break
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,25 @@
+// Package tools implements the built-in tools agents can call: fetching
+// and searching the web, and friends.
+package tools
+
+import "context"
+
+// Result is what every tool's Execute returns. ForLLM is fed back into the
+// model's context; ForUser is what's rendered in the chat UI, which for
+// tools that return rich data is often a richer (e.g. JSON) payload than
+// what the LLM needs to reason about.
+type Result struct {
+	IsError bool
+	ForLLM  string
+	ForUser string
+}
+
+// Tool is implemented by every built-in agent tool.
+type Tool interface {
+	Name() string
+	Execute(ctx context.Context, args map[string]interface{}) Result
+}
+
+func errorResult(msg string) Result {
+	return Result{IsError: true, ForLLM: msg, ForUser: msg}
+}
@@ -197,14 +197,20 @@ func TestWebTool_WebSearch_MissingQuery(t *testing.T) {
 	}
 }
 
-// TestWebTool_WebSearch_ExaProviderSelected verifies Exa is selected when enabled and Brave is disabled
+// TestWebTool_WebSearch_ExaProviderSelected verifies Exa is selected when
+// enabled and Brave is disabled, with fusion explicitly turned off. With
+// fusion on (the default once >=2 providers are enabled, see
+// TestWebTool_WebSearch_Fusion*) Exa and DuckDuckGo results are merged
+// instead of one provider winning outright.
 func TestWebTool_WebSearch_ExaProviderSelected(t *testing.T) {
+	noFusion := false
 	tool := NewWebSearchTool(WebSearchToolOptions{
 		ExaAPIKey:            "exa-test-key",
 		ExaMaxResults:        3,
 		ExaEnabled:           true,
 		DuckDuckGoEnabled:    true,
 		DuckDuckGoMaxResults: 5,
+		FusionEnabled:        &noFusion,
 	})
 
 	if tool == nil {
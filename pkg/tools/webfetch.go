@@ -0,0 +1,163 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/yimiaoxiehou/picoclaw/pkg/config"
+)
+
+// defaultUserAgent is used when no cfg.Tools.Web.Fetch.UserAgent is set.
+const defaultUserAgent = "picoclaw/dev (+https://github.com/yimiaoxiehou/picoclaw)"
+
+// WebFetchTool fetches a single URL and returns its text content, bounded
+// to maxChars.
+type WebFetchTool struct {
+	maxChars      int
+	client        *http.Client
+	userAgent     string
+	respectRobots bool
+	robots        *RobotsChecker
+}
+
+// NewWebFetchTool returns a WebFetchTool with picoclaw's default User-Agent
+// and robots.txt compliance enabled.
+func NewWebFetchTool(maxChars int) *WebFetchTool {
+	return newWebFetchTool(maxChars, defaultUserAgent, true, time.Hour)
+}
+
+// NewWebFetchToolFromConfig returns a WebFetchTool configured per
+// cfg.Tools.Web.Fetch.
+func NewWebFetchToolFromConfig(cfg config.WebFetchConfig, maxChars int) *WebFetchTool {
+	ua := cfg.UserAgent
+	if ua == "" {
+		ua = defaultUserAgent
+	}
+	ttl := time.Duration(cfg.RobotsCacheTTLSeconds) * time.Second
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+	return newWebFetchTool(maxChars, ua, cfg.RespectRobots, ttl)
+}
+
+func newWebFetchTool(maxChars int, userAgent string, respectRobots bool, robotsTTL time.Duration) *WebFetchTool {
+	client := &http.Client{Timeout: 20 * time.Second}
+	return &WebFetchTool{
+		maxChars:      maxChars,
+		client:        client,
+		userAgent:     userAgent,
+		respectRobots: respectRobots,
+		robots:        NewRobotsChecker(client, userAgent, robotsTTL),
+	}
+}
+
+func (t *WebFetchTool) Name() string { return "web_fetch" }
+
+func (t *WebFetchTool) Execute(ctx context.Context, args map[string]interface{}) Result {
+	rawURL, _ := args["url"].(string)
+	if rawURL == "" {
+		return errorResult("url is required")
+	}
+
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return errorResult(fmt.Sprintf("invalid URL %q: %v", rawURL, err))
+	}
+	if target.Scheme != "http" && target.Scheme != "https" {
+		return errorResult(fmt.Sprintf("invalid URL scheme %q: only http/https URLs are supported", target.Scheme))
+	}
+	if target.Host == "" {
+		return errorResult(fmt.Sprintf("invalid URL %q: missing domain", rawURL))
+	}
+
+	respectRobots := t.respectRobots
+	if v, ok := args["respect_robots"].(bool); ok {
+		respectRobots = v
+	}
+	if !respectRobots {
+		log.Printf("web_fetch: robots.txt check bypassed for %s (respect_robots=false)", target.Host)
+	}
+
+	if respectRobots {
+		if allowed, reason := t.robots.Allowed(ctx, target); !allowed {
+			return errorResult(fmt.Sprintf("fetch of %s blocked: %s", rawURL, reason))
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return errorResult(fmt.Sprintf("failed to build request: %v", err))
+	}
+	req.Header.Set("User-Agent", t.userAgent)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return errorResult(fmt.Sprintf("failed to fetch %s: %v", rawURL, err))
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 10*int64(t.maxChars)+1<<20))
+	if err != nil {
+		return errorResult(fmt.Sprintf("failed to read response body: %v", err))
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	text, extractor := extractText(body, contentType)
+
+	truncated := false
+	if len(text) > t.maxChars {
+		text = text[:t.maxChars]
+		truncated = true
+	}
+
+	userPayload, _ := json.Marshal(map[string]interface{}{
+		"text":      text,
+		"truncated": truncated,
+	})
+
+	return Result{
+		IsError: false,
+		ForUser: string(userPayload),
+		ForLLM:  fmt.Sprintf("Fetched %d bytes from %s using the %s extractor (truncated=%v)", len(body), target.Host, extractor, truncated),
+	}
+}
+
+var (
+	scriptOrStyleTag = regexp.MustCompile(`(?is)<(script|style)[^>]*>.*?</(script|style)>`)
+	htmlTag          = regexp.MustCompile(`(?s)<[^>]+>`)
+	whitespaceRun    = regexp.MustCompile(`\s+`)
+)
+
+// extractText turns a fetched body into plain text appropriate to its
+// content type, returning the text plus the name of the extractor used
+// (surfaced to the LLM for transparency).
+func extractText(body []byte, contentType string) (string, string) {
+	switch {
+	case strings.Contains(contentType, "application/json"):
+		var v interface{}
+		if err := json.Unmarshal(body, &v); err == nil {
+			pretty, err := json.MarshalIndent(v, "", "  ")
+			if err == nil {
+				return string(pretty), "json"
+			}
+		}
+		return string(body), "json"
+
+	case strings.Contains(contentType, "text/html"):
+		stripped := scriptOrStyleTag.ReplaceAllString(string(body), " ")
+		stripped = htmlTag.ReplaceAllString(stripped, " ")
+		stripped = whitespaceRun.ReplaceAllString(stripped, " ")
+		return strings.TrimSpace(stripped), "html"
+
+	default:
+		return string(body), "plain"
+	}
+}
@@ -0,0 +1,200 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/yimiaoxiehou/picoclaw/pkg/config"
+)
+
+// WebSearchToolOptions configures which search providers are enabled and
+// how WebSearchTool should combine them.
+type WebSearchToolOptions struct {
+	BraveAPIKey     string
+	BraveMaxResults int
+	BraveEnabled    bool
+
+	ExaAPIKey     string
+	ExaMaxResults int
+	ExaEnabled    bool
+
+	DuckDuckGoMaxResults int
+	DuckDuckGoEnabled    bool
+
+	// FusionEnabled controls whether results from multiple enabled
+	// providers are merged with Reciprocal Rank Fusion. Defaults to true
+	// once two or more providers are enabled; set explicitly to override.
+	FusionEnabled *bool
+}
+
+// searchProvider is implemented by every concrete provider: Search is the
+// single-provider, pre-formatted entry point; searchStructured/providerName
+// (from structuredSearchProvider) back both Search and RRF fusion.
+type searchProvider interface {
+	structuredSearchProvider
+	Search(ctx context.Context, query string, maxResults int) (string, error)
+}
+
+// namedSearchProvider pairs a provider with the display name used in
+// "Results for: ... (via <name>)" and as a fusion source tag.
+type namedSearchProvider struct {
+	name       string
+	provider   searchProvider
+	maxResults int
+}
+
+// WebSearchTool searches the web via one or more configured providers,
+// fusing results across providers when more than one is enabled.
+type WebSearchTool struct {
+	maxResults    int
+	provider      searchProvider        // set when exactly one provider drives results
+	fusionSources []namedSearchProvider // set when fusing across >=2 providers
+}
+
+// NewWebSearchTool builds a WebSearchTool from whichever providers are
+// enabled in opts. It returns nil if none are configured, since agents
+// shouldn't be offered a tool that can never succeed.
+func NewWebSearchTool(opts WebSearchToolOptions) *WebSearchTool {
+	var enabled []namedSearchProvider
+
+	if opts.BraveEnabled && opts.BraveAPIKey != "" {
+		enabled = append(enabled, namedSearchProvider{
+			name:       "Brave",
+			provider:   NewBraveSearchProvider(opts.BraveAPIKey),
+			maxResults: opts.BraveMaxResults,
+		})
+	}
+	if opts.ExaEnabled && opts.ExaAPIKey != "" {
+		enabled = append(enabled, namedSearchProvider{
+			name:       "Exa",
+			provider:   NewExaSearchProvider(opts.ExaAPIKey),
+			maxResults: opts.ExaMaxResults,
+		})
+	}
+	if opts.DuckDuckGoEnabled {
+		enabled = append(enabled, namedSearchProvider{
+			name:       "DuckDuckGo",
+			provider:   NewDuckDuckGoSearchProvider(),
+			maxResults: opts.DuckDuckGoMaxResults,
+		})
+	}
+
+	if len(enabled) == 0 {
+		return nil
+	}
+
+	fusionEnabled := len(enabled) >= 2
+	if opts.FusionEnabled != nil {
+		fusionEnabled = *opts.FusionEnabled
+	}
+
+	maxResults := enabled[0].maxResults
+	for _, e := range enabled[1:] {
+		if e.maxResults > maxResults {
+			maxResults = e.maxResults
+		}
+	}
+
+	if !fusionEnabled || len(enabled) == 1 {
+		return &WebSearchTool{maxResults: enabled[0].maxResults, provider: enabled[0].provider}
+	}
+
+	return &WebSearchTool{maxResults: maxResults, fusionSources: enabled}
+}
+
+// NewWebSearchToolFromConfig builds a WebSearchTool from cfg.Tools.Web.
+func NewWebSearchToolFromConfig(cfg config.WebToolsConfig) *WebSearchTool {
+	return NewWebSearchTool(WebSearchToolOptions{
+		BraveAPIKey:          cfg.Brave.APIKey,
+		BraveMaxResults:      cfg.Brave.MaxResults,
+		BraveEnabled:         cfg.Brave.Enabled,
+		ExaAPIKey:            cfg.Exa.APIKey,
+		ExaMaxResults:        cfg.Exa.MaxResults,
+		ExaEnabled:           cfg.Exa.Enabled,
+		DuckDuckGoMaxResults: cfg.DuckDuckGo.MaxResults,
+		DuckDuckGoEnabled:    cfg.DuckDuckGo.Enabled,
+		FusionEnabled:        cfg.FusionEnabled,
+	})
+}
+
+func (t *WebSearchTool) Name() string { return "web_search" }
+
+func (t *WebSearchTool) Execute(ctx context.Context, args map[string]interface{}) Result {
+	query, _ := args["query"].(string)
+	if query == "" {
+		return errorResult("query is required")
+	}
+
+	if t.fusionSources == nil {
+		text, err := t.provider.Search(ctx, query, t.maxResults)
+		if err != nil {
+			return errorResult(fmt.Sprintf("web search failed: %v", err))
+		}
+		return Result{ForLLM: text, ForUser: text}
+	}
+
+	text := t.executeFused(ctx, query)
+	return Result{ForLLM: text, ForUser: text}
+}
+
+func (t *WebSearchTool) executeFused(ctx context.Context, query string) string {
+	perProvider := make([]providerResults, len(t.fusionSources))
+	var wg sync.WaitGroup
+	var failed []string
+	var mu sync.Mutex
+
+	for i, src := range t.fusionSources {
+		i, src := i, src
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results, err := src.provider.searchStructured(ctx, query, src.maxResults)
+			if err != nil {
+				mu.Lock()
+				failed = append(failed, src.name)
+				mu.Unlock()
+				return
+			}
+			perProvider[i] = providerResults{provider: src.name, results: results}
+		}()
+	}
+	wg.Wait()
+
+	// Drop providers that failed (their slot was never filled), preserving
+	// the configured order for deterministic dedup winners.
+	nonEmpty := make([]providerResults, 0, len(perProvider))
+	for _, pr := range perProvider {
+		if pr.provider != "" {
+			nonEmpty = append(nonEmpty, pr)
+		}
+	}
+
+	merged := fuseRRF(nonEmpty, t.maxResults)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Results for: %s (fused via %s)\n\n", query, fusionSourceNames(t.fusionSources))
+	if len(failed) > 0 {
+		fmt.Fprintf(&b, "(providers unavailable: %s)\n\n", strings.Join(failed, ", "))
+	}
+	for i, r := range merged {
+		fmt.Fprintf(&b, "%d. %s\n%s\n", i+1, r.Title, r.URL)
+		if r.Snippet != "" {
+			fmt.Fprintf(&b, "%s\n", r.Snippet)
+		}
+		fmt.Fprintf(&b, "sources: %s\n", strings.Join(r.Sources, ", "))
+		if i != len(merged)-1 {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+func fusionSourceNames(sources []namedSearchProvider) string {
+	names := make([]string, len(sources))
+	for i, s := range sources {
+		names[i] = s.name
+	}
+	return strings.Join(names, ", ")
+}
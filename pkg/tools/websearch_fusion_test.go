@@ -0,0 +1,155 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestSearchTool(t *testing.T, braveServer, exaServer *httptest.Server) *WebSearchTool {
+	t.Helper()
+
+	brave := &BraveSearchProvider{apiKey: "brave-key", endpoint: braveServer.URL}
+	exa := &ExaSearchProvider{apiKey: "exa-key", endpoint: exaServer.URL}
+
+	return &WebSearchTool{
+		maxResults: 5,
+		fusionSources: []namedSearchProvider{
+			{name: "Brave", provider: brave, maxResults: 5},
+			{name: "Exa", provider: exa, maxResults: 5},
+		},
+	}
+}
+
+func braveServerWithResults(t *testing.T, urls []string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		type result struct {
+			Title       string `json:"title"`
+			URL         string `json:"url"`
+			Description string `json:"description"`
+		}
+		results := make([]result, len(urls))
+		for i, u := range urls {
+			results[i] = result{Title: "Brave result " + u, URL: u, Description: "snippet"}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"web": map[string]interface{}{"results": results},
+		})
+	}))
+}
+
+func exaServerWithResults(t *testing.T, urls []string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		type result struct {
+			Title string `json:"title"`
+			URL   string `json:"url"`
+			Text  string `json:"text"`
+		}
+		results := make([]result, len(urls))
+		for i, u := range urls {
+			results[i] = result{Title: "Exa result " + u, URL: u, Text: "snippet"}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+	}))
+}
+
+// TestWebTool_WebSearch_Fusion_DedupesDuplicateURLs verifies a URL
+// surfaced by both providers collapses into a single fused result.
+func TestWebTool_WebSearch_Fusion_DedupesDuplicateURLs(t *testing.T) {
+	brave := braveServerWithResults(t, []string{"https://example.com/a"})
+	defer brave.Close()
+	exa := exaServerWithResults(t, []string{"https://example.com/a?utm_source=newsletter"})
+	defer exa.Close()
+
+	tool := newTestSearchTool(t, brave, exa)
+	result := tool.Execute(context.Background(), map[string]interface{}{"query": "test"})
+
+	if result.IsError {
+		t.Fatalf("expected success, got: %+v", result)
+	}
+	urlLine := "\nhttps://example.com/a\n"
+	if strings.Count(result.ForLLM, urlLine) != 1 {
+		t.Errorf("expected duplicate URL to be deduped to a single entry, got:\n%s", result.ForLLM)
+	}
+	if !strings.Contains(result.ForLLM, "sources: Brave, Exa") {
+		t.Errorf("expected fused result to list both sources, got:\n%s", result.ForLLM)
+	}
+}
+
+// TestWebTool_WebSearch_Fusion_CombinedRankBeatsSingleProviderTop verifies
+// a URL ranked highly by both providers outranks a URL that's #1 on only
+// one of them.
+func TestWebTool_WebSearch_Fusion_CombinedRankBeatsSingleProviderTop(t *testing.T) {
+	brave := braveServerWithResults(t, []string{
+		"https://only-brave.com/",
+		"https://both.com/",
+	})
+	defer brave.Close()
+	exa := exaServerWithResults(t, []string{
+		"https://both.com/",
+		"https://only-exa.com/",
+		"https://also-only-exa.com/",
+	})
+	defer exa.Close()
+
+	tool := newTestSearchTool(t, brave, exa)
+	result := tool.Execute(context.Background(), map[string]interface{}{"query": "test"})
+
+	if result.IsError {
+		t.Fatalf("expected success, got: %+v", result)
+	}
+
+	bothIdx := strings.Index(result.ForLLM, "both.com")
+	onlyBraveIdx := strings.Index(result.ForLLM, "only-brave.com")
+	if bothIdx == -1 || onlyBraveIdx == -1 {
+		t.Fatalf("expected both URLs present, got:\n%s", result.ForLLM)
+	}
+	if bothIdx > onlyBraveIdx {
+		t.Errorf("expected the URL ranked by both providers to outrank the URL ranked #1 by only one, got:\n%s", result.ForLLM)
+	}
+}
+
+// TestWebTool_WebSearch_Fusion_DegradesOnProviderFailure verifies that one
+// provider failing doesn't fail the whole search.
+func TestWebTool_WebSearch_Fusion_DegradesOnProviderFailure(t *testing.T) {
+	brave := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("boom"))
+	}))
+	defer brave.Close()
+	exa := exaServerWithResults(t, []string{"https://example.com/a"})
+	defer exa.Close()
+
+	tool := newTestSearchTool(t, brave, exa)
+	result := tool.Execute(context.Background(), map[string]interface{}{"query": "test"})
+
+	if result.IsError {
+		t.Fatalf("expected the call to succeed despite one provider failing, got: %+v", result)
+	}
+	if !strings.Contains(result.ForLLM, "example.com/a") {
+		t.Errorf("expected surviving provider's result to be present, got:\n%s", result.ForLLM)
+	}
+	if !strings.Contains(result.ForLLM, "providers unavailable: Brave") {
+		t.Errorf("expected failed provider to be noted, got:\n%s", result.ForLLM)
+	}
+}
+
+func TestNormalizeSearchURL(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"https://Example.com/a/", "https://example.com/a"},
+		{"https://example.com/a?utm_source=x&b=1", "https://example.com/a?b=1"},
+		{"https://example.com/a#section", "https://example.com/a"},
+	}
+	for _, tt := range tests {
+		if got := normalizeSearchURL(tt.in); got != tt.want {
+			t.Errorf("normalizeSearchURL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}